@@ -0,0 +1,349 @@
+package nginx
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Client wraps the SSH connection used to manage the remote NGINX host.
+type Client struct {
+	SSHClient *ssh.Client
+
+	// NginxConfPath is the main nginx.conf used when validating changes with
+	// `nginx -t -c <path>`. Set by the provider during Configure.
+	NginxConfPath string
+}
+
+// BastionConfig describes an optional jump host that NewClient tunnels
+// through to reach the target NGINX host.
+type BastionConfig struct {
+	Host                 string
+	User                 string
+	Password             string
+	PrivateKey           string
+	PrivateKeyPath       string
+	PrivateKeyPassphrase string
+}
+
+// ClientConfig collects every way NewClient can authenticate to, and reach,
+// the target host.
+type ClientConfig struct {
+	Host string
+	User string
+
+	Password             string
+	PrivateKey           string
+	PrivateKeyPath       string
+	PrivateKeyPassphrase string
+	UseSSHAgent          bool
+	KnownHosts           string
+	Bastion              *BastionConfig
+}
+
+// NewClient creates a new SSH client, authenticating with whichever of
+// Password, PrivateKey(Path), or the SSH agent is configured, optionally
+// tunneling through a bastion host, and verifying the host key against
+// KnownHosts when set.
+func NewClient(cfg ClientConfig) (*Client, error) {
+	auth, err := authMethods(cfg.Password, cfg.PrivateKey, cfg.PrivateKeyPath, cfg.PrivateKeyPassphrase, cfg.UseSSHAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	hkCallback, err := hostKeyCallback(cfg.KnownHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ssh.ClientConfig{
+		User:            cfg.User,
+		Auth:            auth,
+		HostKeyCallback: hkCallback,
+	}
+
+	targetAddr := fmt.Sprintf("%s:22", cfg.Host)
+
+	if cfg.Bastion == nil {
+		conn, err := ssh.Dial("tcp", targetAddr, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to SSH server: %w", err)
+		}
+		return &Client{SSHClient: conn}, nil
+	}
+
+	bastionAuth, err := authMethods(cfg.Bastion.Password, cfg.Bastion.PrivateKey, cfg.Bastion.PrivateKeyPath, cfg.Bastion.PrivateKeyPassphrase, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure bastion authentication: %w", err)
+	}
+
+	bastionConfig := &ssh.ClientConfig{
+		User:            cfg.Bastion.User,
+		Auth:            bastionAuth,
+		HostKeyCallback: hkCallback,
+	}
+
+	bastionConn, err := ssh.Dial("tcp", fmt.Sprintf("%s:22", cfg.Bastion.Host), bastionConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bastion host: %w", err)
+	}
+
+	targetConn, err := bastionConn.Dial("tcp", targetAddr)
+	if err != nil {
+		bastionConn.Close()
+		return nil, fmt.Errorf("failed to dial target host %s through bastion: %w", targetAddr, err)
+	}
+
+	ncc, chans, reqs, err := ssh.NewClientConn(targetConn, targetAddr, config)
+	if err != nil {
+		bastionConn.Close()
+		return nil, fmt.Errorf("failed to establish SSH connection to %s through bastion: %w", targetAddr, err)
+	}
+
+	return &Client{SSHClient: ssh.NewClient(ncc, chans, reqs)}, nil
+}
+
+// authMethods builds the ssh.AuthMethod list for one set of credentials,
+// preferring an explicit password, then a private key, then the SSH agent.
+func authMethods(password, privateKey, privateKeyPath, passphrase string, useAgent bool) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	if password != "" {
+		methods = append(methods, ssh.Password(password))
+	}
+
+	if privateKey != "" || privateKeyPath != "" {
+		signer, err := parsePrivateKey(privateKey, privateKeyPath, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+
+	if useAgent {
+		signers, err := agentSigners()
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, ssh.PublicKeysCallback(func() ([]ssh.Signer, error) { return signers, nil }))
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no SSH authentication method configured: set password, private_key(_path), or use_ssh_agent")
+	}
+
+	return methods, nil
+}
+
+func parsePrivateKey(pemKey, path, passphrase string) (ssh.Signer, error) {
+	keyBytes := []byte(pemKey)
+	if path != "" {
+		var err error
+		keyBytes, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key file %s: %w", path, err)
+		}
+	}
+
+	if passphrase != "" {
+		signer, err := ssh.ParsePrivateKeyWithPassphrase(keyBytes, []byte(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		return signer, nil
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return signer, nil
+}
+
+func agentSigners() ([]ssh.Signer, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, fmt.Errorf("use_ssh_agent is set but SSH_AUTH_SOCK is not set")
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial SSH agent at %s: %w", socket, err)
+	}
+
+	signers, err := agent.NewClient(conn).Signers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SSH agent signers: %w", err)
+	}
+
+	return signers, nil
+}
+
+// hostKeyCallback builds a ssh.HostKeyCallback from knownHosts, which may be
+// a path to a known_hosts file or its content inline. Empty means no
+// verification, which callers should treat as insecure.
+func hostKeyCallback(knownHosts string) (ssh.HostKeyCallback, error) {
+	if knownHosts == "" {
+		return ssh.InsecureIgnoreHostKey(), nil // Do not use in production
+	}
+
+	path := knownHosts
+	if _, err := os.Stat(knownHosts); err != nil {
+		tempFile, err := os.CreateTemp("", "nginx-provider-known-hosts-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to stage known_hosts content: %w", err)
+		}
+		defer os.Remove(tempFile.Name())
+
+		if _, err := tempFile.WriteString(knownHosts); err != nil {
+			tempFile.Close()
+			return nil, fmt.Errorf("failed to stage known_hosts content: %w", err)
+		}
+		if err := tempFile.Close(); err != nil {
+			return nil, fmt.Errorf("failed to stage known_hosts content: %w", err)
+		}
+		path = tempFile.Name()
+	}
+
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse known_hosts: %w", err)
+	}
+
+	return callback, nil
+}
+
+// RunCommand executes a command on the remote server
+func (c *Client) RunCommand(command string) (string, error) {
+	session, err := c.SSHClient.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("failed to create SSH session: %w", err)
+	}
+	defer session.Close()
+
+	stdout, err := session.CombinedOutput(command)
+	if err != nil {
+		return string(stdout), fmt.Errorf("failed to execute command '%s': %w", command, err)
+	}
+
+	return string(stdout), nil
+}
+
+// UploadFile writes content to remotePath over SFTP. It writes to a
+// "remotePath.tmp" sibling first, chmods it, and renames it into place so
+// that readers never observe a partially written file.
+//
+// Note: this assumes the SSH login user already has direct write access to
+// the destination directory (e.g. the config tree is group-writable, or the
+// session is already root) — unlike the shell commands elsewhere in these
+// resources, UploadFile has no `sudo` escalation of its own, since SFTP
+// writes can't be piped through sudo.
+func (c *Client) UploadFile(remotePath string, content []byte, mode os.FileMode) error {
+	sftpClient, err := sftp.NewClient(c.SSHClient)
+	if err != nil {
+		return fmt.Errorf("failed to start SFTP session: %w", err)
+	}
+	defer sftpClient.Close()
+
+	tempPath := remotePath + ".tmp"
+
+	f, err := sftpClient.Create(tempPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", tempPath, err)
+	}
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", tempPath, err)
+	}
+
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to chmod %s: %w", tempPath, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", tempPath, err)
+	}
+
+	// remotePath almost always already exists (every resource re-uploads to
+	// the same path on Update), and the plain SFTP rename refuses to replace
+	// an existing file. Prefer the posix-rename@openssh.com extension, which
+	// OpenSSH's sftp-server supports and which renames atomically even when
+	// the destination exists; fall back to remove-then-rename for servers
+	// that don't advertise it.
+	if err := sftpClient.PosixRename(tempPath, remotePath); err != nil {
+		if rmErr := sftpClient.Remove(remotePath); rmErr != nil && !os.IsNotExist(rmErr) {
+			return fmt.Errorf("failed to remove existing %s before rename: %w", remotePath, rmErr)
+		}
+		if err := sftpClient.Rename(tempPath, remotePath); err != nil {
+			return fmt.Errorf("failed to rename %s into place at %s: %w", tempPath, remotePath, err)
+		}
+	}
+
+	return nil
+}
+
+// DownloadFile reads the content of remotePath over SFTP.
+func (c *Client) DownloadFile(remotePath string) ([]byte, error) {
+	sftpClient, err := sftp.NewClient(c.SSHClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start SFTP session: %w", err)
+	}
+	defer sftpClient.Close()
+
+	f, err := sftpClient.Open(remotePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", remotePath, err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", remotePath, err)
+	}
+
+	return content, nil
+}
+
+// ValidateConfig runs `nginx -t -c <path>` on the remote host, returning the
+// combined stdout/stderr output so callers can surface it as a diagnostic.
+func (c *Client) ValidateConfig(confPath string) (string, error) {
+	if confPath == "" {
+		confPath = c.NginxConfPath
+	}
+	return c.RunCommand(fmt.Sprintf("nginx -t -c %s", confPath))
+}
+
+// Reload runs `sudo systemctl reload nginx` (or `restart` when strategy is
+// "restart") on the remote host. strategy == "none" is a no-op: some callers
+// let another resource in the same apply trigger the actual reload. There is
+// no apply-wide coalescing here — this repo has no provider-level
+// apply-complete hook to flush a deferred reload from, so every resource
+// that changes live nginx state reloads inline, the same as nginx_upstream.
+func (c *Client) Reload(strategy string) error {
+	if strategy == "" || strategy == "none" {
+		return nil
+	}
+
+	action := "reload"
+	if strategy == "restart" {
+		action = "restart"
+	}
+	if _, err := c.RunCommand(fmt.Sprintf("sudo systemctl %s nginx", action)); err != nil {
+		return fmt.Errorf("failed to %s nginx: %w", action, err)
+	}
+
+	return nil
+}
+
+// Close closes the SSH connection.
+func (c *Client) Close() error {
+	return c.SSHClient.Close()
+}
@@ -3,8 +3,8 @@ package nginx
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -105,16 +105,8 @@ func (r *NginxConfResource) Create(ctx context.Context, req resource.CreateReque
 	}
 }`, data.ListenPort.ValueInt64(), data.ServerName.ValueString(), data.Root.ValueString())
 
-	tempFilePath := "/tmp/nginx_temp.conf"
-	uploadCommand := fmt.Sprintf("echo '%s' > %s", shellEscape(configContent), tempFilePath)
-	if _, err := r.client.RunCommand(uploadCommand); err != nil {
-		resp.Diagnostics.AddError("Error uploading configuration", fmt.Sprintf("Failed to upload configuration to %s: %v", tempFilePath, err))
-		return
-	}
-
-	moveCommand := fmt.Sprintf("sudo mv %s %s", tempFilePath, data.Path.ValueString())
-	if _, err := r.client.RunCommand(moveCommand); err != nil {
-		resp.Diagnostics.AddError("Error moving configuration", fmt.Sprintf("Failed to move configuration to %s: %v", data.Path.ValueString(), err))
+	if diags := r.writeAndValidate(data.Path.ValueString(), []byte(configContent)); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
 		return
 	}
 	//	r.client.ReloadNginx()
@@ -130,14 +122,13 @@ func (r *NginxConfResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	command := fmt.Sprintf("cat %s", data.Path.ValueString())
-	stdout, err := r.client.RunCommand(command)
+	content, err := r.client.DownloadFile(data.Path.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading configuration", fmt.Sprintf("Failed to read configuration at %s: %v", data.Path.ValueString(), err))
 		return
 	}
 
-	data.Content = types.StringValue(stdout)
+	data.Content = types.StringValue(string(content))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -148,17 +139,46 @@ func (r *NginxConfResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	configContent := data.Content.ValueString()
-	escapedContent := shellEscape(configContent)
-	command := fmt.Sprintf("echo '%s' > %s", escapedContent, data.Path.ValueString())
-	if _, err := r.client.RunCommand(command); err != nil {
-		resp.Diagnostics.AddError("Error updating configuration", fmt.Sprintf("Failed to update configuration at %s: %v", data.Path.ValueString(), err))
+	if diags := r.writeAndValidate(data.Path.ValueString(), []byte(data.Content.ValueString())); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
 		return
 	}
 	//	r.client.ReloadNginx()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// writeAndValidate backs up any existing file at path, uploads the new
+// content, and runs `nginx -t`. If validation fails, the previous content
+// (or absence of the file) is restored and the stderr is surfaced as a
+// diagnostic.
+func (r *NginxConfResource) writeAndValidate(path string, content []byte) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	backup, hadExisting := r.client.DownloadFile(path)
+	existed := hadExisting == nil
+
+	if err := r.client.UploadFile(path, content, 0644); err != nil {
+		diags.AddError("Error uploading configuration", fmt.Sprintf("Failed to upload configuration to %s: %v", path, err))
+		return diags
+	}
+
+	if output, err := r.client.ValidateConfig(""); err != nil {
+		if existed {
+			if restoreErr := r.client.UploadFile(path, backup, 0644); restoreErr != nil {
+				diags.AddError("Error rolling back configuration", fmt.Sprintf("nginx -t failed (%s) and restoring the previous content at %s also failed: %v", output, path, restoreErr))
+				return diags
+			}
+		} else if _, rmErr := r.client.RunCommand(fmt.Sprintf("rm -f %s", path)); rmErr != nil {
+			diags.AddError("Error rolling back configuration", fmt.Sprintf("nginx -t failed (%s) and removing the invalid file at %s also failed: %v", output, path, rmErr))
+			return diags
+		}
+		diags.AddError("NGINX configuration test failed", fmt.Sprintf("nginx -t rejected the configuration at %s, previous state restored:\n%s", path, output))
+		return diags
+	}
+
+	return diags
+}
+
 func (r *NginxConfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data NginxConfResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -178,7 +198,3 @@ func (r *NginxConfResource) Delete(ctx context.Context, req resource.DeleteReque
 func (r *NginxConfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
-
-func shellEscape(content string) string {
-	return strings.ReplaceAll(content, "'", "'\\''")
-}
@@ -0,0 +1,140 @@
+package nginx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NginxConfDataSource{}
+
+func NewNginxConfDataSource() datasource.DataSource {
+	return &NginxConfDataSource{}
+}
+
+// NginxConfDataSource reads an existing configuration file from the remote
+// host without managing it.
+type NginxConfDataSource struct {
+	client *Client
+}
+
+// NginxConfDataSourceModel describes the data source data model.
+type NginxConfDataSourceModel struct {
+	Path         types.String   `tfsdk:"path"`
+	Content      types.String   `tfsdk:"content"`
+	Sha256       types.String   `tfsdk:"sha256"`
+	LastModified types.String   `tfsdk:"last_modified"`
+	ServerNames  []types.String `tfsdk:"server_names"`
+	ListenPorts  []types.Int64  `tfsdk:"listen_ports"`
+}
+
+func (d *NginxConfDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nginx_conf"
+}
+
+func (d *NginxConfDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing NGINX configuration file from the remote host.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path to the configuration file on the remote host.",
+				Required:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Raw content of the configuration file.",
+				Computed:            true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the file content.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "Last modification time of the file, as reported by `stat` on the remote host.",
+				Computed:            true,
+			},
+			"server_names": schema.ListAttribute{
+				MarkdownDescription: "`server_name` values found in the file.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"listen_ports": schema.ListAttribute{
+				MarkdownDescription: "`listen` ports found in the file.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *NginxConfDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+var (
+	serverNameRe = regexp.MustCompile(`(?m)^\s*server_name\s+([^;]+);`)
+	listenRe     = regexp.MustCompile(`(?m)^\s*listen\s+(?:\S*:)?(\d+)`)
+)
+
+func (d *NginxConfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NginxConfDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := d.client.DownloadFile(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading configuration", fmt.Sprintf("Failed to read configuration at %s: %v", data.Path.ValueString(), err))
+		return
+	}
+
+	lastModified, err := d.client.RunCommand(fmt.Sprintf("stat -c %%y %s", data.Path.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading configuration metadata", fmt.Sprintf("Failed to stat %s: %v", data.Path.ValueString(), err))
+		return
+	}
+
+	sum := sha256.Sum256(content)
+
+	data.Content = types.StringValue(string(content))
+	data.Sha256 = types.StringValue(hex.EncodeToString(sum[:]))
+	data.LastModified = types.StringValue(trimNewline(lastModified))
+
+	for _, match := range serverNameRe.FindAllStringSubmatch(string(content), -1) {
+		for _, name := range strings.Fields(match[1]) {
+			data.ServerNames = append(data.ServerNames, types.StringValue(name))
+		}
+	}
+
+	for _, match := range listenRe.FindAllStringSubmatch(string(content), -1) {
+		var port int64
+		if _, err := fmt.Sscanf(match[1], "%d", &port); err == nil {
+			data.ListenPorts = append(data.ListenPorts, types.Int64Value(port))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func trimNewline(s string) string {
+	return strings.TrimRight(s, "\r\n")
+}
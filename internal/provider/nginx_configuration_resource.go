@@ -0,0 +1,383 @@
+package nginx
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NginxConfigurationResource{}
+
+func NewNginxConfigurationResource() resource.Resource {
+	return &NginxConfigurationResource{}
+}
+
+// NginxConfigurationResource defines the resource implementation.
+type NginxConfigurationResource struct {
+	client *Client
+}
+
+// NginxConfigurationResourceModel describes the resource data model.
+type NginxConfigurationResourceModel struct {
+	ConfigFile    []NginxConfigFileModel    `tfsdk:"config_file"`
+	ProtectedFile []NginxProtectedFileModel `tfsdk:"protected_file"`
+	PackageData   types.String              `tfsdk:"package_data"`
+	RootFile      types.String              `tfsdk:"root_file"`
+	Id            types.String              `tfsdk:"id"`
+}
+
+// NginxConfigFileModel describes a single `config_file` block.
+type NginxConfigFileModel struct {
+	Content     types.String `tfsdk:"content"`
+	VirtualPath types.String `tfsdk:"virtual_path"`
+	Hash        types.String `tfsdk:"hash"`
+}
+
+// NginxProtectedFileModel describes a single `protected_file` block, written
+// with restrictive permissions because it may carry secrets.
+type NginxProtectedFileModel struct {
+	Content     types.String `tfsdk:"content"`
+	VirtualPath types.String `tfsdk:"virtual_path"`
+	Hash        types.String `tfsdk:"hash"`
+}
+
+func (r *NginxConfigurationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration"
+}
+
+func (r *NginxConfigurationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a whole tree of NGINX configuration files in one resource, rather than one file at a time.",
+		Attributes: map[string]schema.Attribute{
+			"package_data": schema.StringAttribute{
+				MarkdownDescription: "Base64-encoded tar.gz archive extracted onto the host. Extraction is atomic: the previous tree is snapshotted first and restored if `nginx -t` fails.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"root_file": schema.StringAttribute{
+				MarkdownDescription: "Virtual path of the `config_file` or `protected_file` entry that is the primary `nginx.conf`, used when invoking `nginx -t`.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource ID, which is the root_file path.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"config_file": schema.ListNestedBlock{
+				MarkdownDescription: "A plain configuration file, identified by its virtual path under the NGINX config root.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							MarkdownDescription: "File content.",
+							Required:            true,
+						},
+						"virtual_path": schema.StringAttribute{
+							MarkdownDescription: "Path of the file relative to the NGINX config root.",
+							Required:            true,
+						},
+						"hash": schema.StringAttribute{
+							MarkdownDescription: "SHA-256 hash of `content`, used to detect which files changed between applies.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"protected_file": schema.ListNestedBlock{
+				MarkdownDescription: "A sensitive configuration file (e.g. containing credentials), written with `0600` permissions.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							MarkdownDescription: "File content.",
+							Required:            true,
+							Sensitive:           true,
+						},
+						"virtual_path": schema.StringAttribute{
+							MarkdownDescription: "Path of the file relative to the NGINX config root.",
+							Required:            true,
+						},
+						"hash": schema.StringAttribute{
+							MarkdownDescription: "SHA-256 hash of `content`, used to detect which files changed between applies.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *NginxConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// configRoot is where config_file/protected_file virtual paths are rooted.
+const configRoot = "/etc/nginx"
+
+func (r *NginxConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NginxConfigurationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &data, nil); err != nil {
+		resp.Diagnostics.AddError("Error applying NGINX configuration tree", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(data.RootFile.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NginxConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NginxConfigurationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prior NginxConfigurationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &data, &prior); err != nil {
+		resp.Diagnostics.AddError("Error applying NGINX configuration tree", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(data.RootFile.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apply computes the hash of every file, uploads only the ones that changed
+// relative to prior (nil on Create, meaning everything is new), snapshots the
+// previous tree into a tarball, extracts package_data if present, validates
+// with `nginx -t`, and restores the snapshot if validation fails.
+func (r *NginxConfigurationResource) apply(ctx context.Context, data, prior *NginxConfigurationResourceModel) error {
+	priorHashes := map[string]string{}
+	if prior != nil {
+		for _, f := range prior.ConfigFile {
+			priorHashes[f.VirtualPath.ValueString()] = f.Hash.ValueString()
+		}
+		for _, f := range prior.ProtectedFile {
+			priorHashes[f.VirtualPath.ValueString()] = f.Hash.ValueString()
+		}
+	}
+
+	snapshotPath := fmt.Sprintf("/tmp/nginx_configuration_snapshot_%s.tar.gz", shortHash(data.RootFile.ValueString()))
+	if _, err := r.client.RunCommand(fmt.Sprintf("tar -czf %s -C %s .", snapshotPath, configRoot)); err != nil {
+		return fmt.Errorf("failed to snapshot current configuration tree: %w", err)
+	}
+
+	// newPaths collects virtual paths this apply introduces that weren't in
+	// prior. Restoring the snapshot only overwrites files it contains, so
+	// rollback deletes these explicitly first, then restores the snapshot, so
+	// that a path which already existed on disk comes back from the snapshot
+	// while one that didn't does not survive a failed apply.
+	var newPaths []string
+
+	rollback := func(cause error) error {
+		for _, virtualPath := range newPaths {
+			remotePath := fmt.Sprintf("%s/%s", configRoot, virtualPath)
+			if _, restoreErr := r.client.RunCommand(fmt.Sprintf("rm -f %s", remotePath)); restoreErr != nil {
+				return fmt.Errorf("%w (additionally failed to remove %s added by the failed apply: %v)", cause, remotePath, restoreErr)
+			}
+		}
+		if _, restoreErr := r.client.RunCommand(fmt.Sprintf("tar -xzf %s -C %s", snapshotPath, configRoot)); restoreErr != nil {
+			return fmt.Errorf("%w (additionally failed to roll back: %v)", cause, restoreErr)
+		}
+		return cause
+	}
+
+	for i := range data.ConfigFile {
+		f := &data.ConfigFile[i]
+		f.Hash = types.StringValue(hashContent(f.Content.ValueString()))
+		if _, existed := priorHashes[f.VirtualPath.ValueString()]; !existed {
+			newPaths = append(newPaths, f.VirtualPath.ValueString())
+		}
+		if priorHashes[f.VirtualPath.ValueString()] == f.Hash.ValueString() {
+			continue
+		}
+		if err := r.writeConfigTreeFile(f.VirtualPath.ValueString(), []byte(f.Content.ValueString()), 0644); err != nil {
+			return rollback(err)
+		}
+	}
+
+	for i := range data.ProtectedFile {
+		f := &data.ProtectedFile[i]
+		f.Hash = types.StringValue(hashContent(f.Content.ValueString()))
+		if _, existed := priorHashes[f.VirtualPath.ValueString()]; !existed {
+			newPaths = append(newPaths, f.VirtualPath.ValueString())
+		}
+		if priorHashes[f.VirtualPath.ValueString()] == f.Hash.ValueString() {
+			continue
+		}
+		if err := r.writeConfigTreeFile(f.VirtualPath.ValueString(), []byte(f.Content.ValueString()), 0600); err != nil {
+			return rollback(err)
+		}
+	}
+
+	if pkg := data.PackageData.ValueString(); pkg != "" {
+		if err := r.extractPackageData(pkg); err != nil {
+			return rollback(err)
+		}
+	}
+
+	if output, err := r.client.RunCommand(fmt.Sprintf("nginx -t -c %s/%s", configRoot, data.RootFile.ValueString())); err != nil {
+		return rollback(fmt.Errorf("nginx configuration test failed: %s: %w", output, err))
+	}
+
+	if _, err := r.client.RunCommand("sudo systemctl reload nginx"); err != nil {
+		return rollback(fmt.Errorf("failed to reload nginx: %w", err))
+	}
+
+	if _, err := r.client.RunCommand(fmt.Sprintf("rm -f %s", snapshotPath)); err != nil {
+		return fmt.Errorf("applied successfully but failed to clean up snapshot %s: %w", snapshotPath, err)
+	}
+
+	return nil
+}
+
+func (r *NginxConfigurationResource) writeConfigTreeFile(virtualPath string, content []byte, mode os.FileMode) error {
+	remotePath := fmt.Sprintf("%s/%s", configRoot, virtualPath)
+	if err := r.client.UploadFile(remotePath, content, mode); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", virtualPath, err)
+	}
+	return nil
+}
+
+// extractPackageData decodes the base64 tar.gz payload, validates it unpacks
+// cleanly, uploads it to the host, and extracts it over the config root.
+func (r *NginxConfigurationResource) extractPackageData(encoded string) error {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("package_data is not valid base64: %w", err)
+	}
+
+	if err := validateTarGz(raw); err != nil {
+		return fmt.Errorf("package_data is not a valid tar.gz archive: %w", err)
+	}
+
+	remoteArchive := "/tmp/nginx_configuration_package.tar.gz"
+	if err := r.client.UploadFile(remoteArchive, raw, 0644); err != nil {
+		return fmt.Errorf("failed to upload package_data: %w", err)
+	}
+
+	if _, err := r.client.RunCommand(fmt.Sprintf("tar -xzf %s -C %s", remoteArchive, configRoot)); err != nil {
+		return fmt.Errorf("failed to extract package_data: %w", err)
+	}
+
+	return nil
+}
+
+// validateTarGz does a dry read of the archive so malformed package_data is
+// rejected before the archive is uploaded to the host.
+func validateTarGz(raw []byte) error {
+	gzr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		_, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func hashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func (r *NginxConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NginxConfigurationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i := range data.ConfigFile {
+		f := &data.ConfigFile[i]
+		content, err := r.client.DownloadFile(fmt.Sprintf("%s/%s", configRoot, f.VirtualPath.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading configuration file", fmt.Sprintf("Failed to read %s: %v", f.VirtualPath.ValueString(), err))
+			return
+		}
+		f.Content = types.StringValue(string(content))
+		f.Hash = types.StringValue(hashContent(string(content)))
+	}
+
+	for i := range data.ProtectedFile {
+		f := &data.ProtectedFile[i]
+		content, err := r.client.DownloadFile(fmt.Sprintf("%s/%s", configRoot, f.VirtualPath.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading protected file", fmt.Sprintf("Failed to read %s: %v", f.VirtualPath.ValueString(), err))
+			return
+		}
+		f.Content = types.StringValue(string(content))
+		f.Hash = types.StringValue(hashContent(string(content)))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NginxConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NginxConfigurationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, f := range data.ConfigFile {
+		if _, err := r.client.RunCommand(fmt.Sprintf("sudo rm -f %s/%s", configRoot, f.VirtualPath.ValueString())); err != nil {
+			resp.Diagnostics.AddError("Error deleting configuration file", fmt.Sprintf("Failed to delete %s: %v", f.VirtualPath.ValueString(), err))
+			return
+		}
+	}
+	for _, f := range data.ProtectedFile {
+		if _, err := r.client.RunCommand(fmt.Sprintf("sudo rm -f %s/%s", configRoot, f.VirtualPath.ValueString())); err != nil {
+			resp.Diagnostics.AddError("Error deleting protected file", fmt.Sprintf("Failed to delete %s: %v", f.VirtualPath.ValueString(), err))
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,88 @@
+package nginx
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+)
+
+func TestHashContent(t *testing.T) {
+	got := hashContent("hello")
+	want := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if got != want {
+		t.Errorf("hashContent(%q) = %q, want %q", "hello", got, want)
+	}
+
+	if hashContent("a") == hashContent("b") {
+		t.Error("hashContent() collided for distinct inputs")
+	}
+}
+
+func TestShortHash(t *testing.T) {
+	got := shortHash("nginx.conf")
+	if len(got) != 12 {
+		t.Errorf("shortHash() returned %d chars, want 12", len(got))
+	}
+	if got != shortHash("nginx.conf") {
+		t.Error("shortHash() is not deterministic for the same input")
+	}
+	if got == shortHash("other.conf") {
+		t.Error("shortHash() collided for distinct inputs")
+	}
+}
+
+func buildTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0644}); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestValidateTarGz_Valid(t *testing.T) {
+	raw := buildTarGz(t, map[string]string{"nginx.conf": "events {}\n"})
+
+	if err := validateTarGz(raw); err != nil {
+		t.Errorf("validateTarGz() returned error for a well-formed archive: %v", err)
+	}
+}
+
+func TestValidateTarGz_NotGzip(t *testing.T) {
+	if err := validateTarGz([]byte("not a gzip stream")); err == nil {
+		t.Error("validateTarGz() with non-gzip input: expected error, got nil")
+	}
+}
+
+func TestValidateTarGz_TruncatedTar(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	if _, err := gzw.Write([]byte("not a tar archive")); err != nil {
+		t.Fatalf("failed to write gzip content: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	if err := validateTarGz(buf.Bytes()); err == nil {
+		t.Error("validateTarGz() with a gzip stream that isn't a tar archive: expected error, got nil")
+	}
+}
@@ -0,0 +1,278 @@
+package nginx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NginxSiteResource{}
+var _ resource.ResourceWithImportState = &NginxSiteResource{}
+
+func NewNginxSiteResource() resource.Resource {
+	return &NginxSiteResource{}
+}
+
+// NginxSiteResource defines the resource implementation.
+type NginxSiteResource struct {
+	client *Client
+}
+
+// NginxSiteResourceModel describes the resource data model.
+type NginxSiteResourceModel struct {
+	Name             types.String `tfsdk:"name"`
+	Content          types.String `tfsdk:"content"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	AvailablePath    types.String `tfsdk:"available_path"`
+	EnabledPath      types.String `tfsdk:"enabled_path"`
+	ReloadStrategy   types.String `tfsdk:"reload_strategy"`
+	TestBeforeReload types.Bool   `tfsdk:"test_before_reload"`
+	Id               types.String `tfsdk:"id"`
+}
+
+// sitesAvailableDir and sitesEnabledDir are the conventional Debian/Ubuntu
+// NGINX directories this resource writes into and symlinks between.
+const (
+	sitesAvailableDir = "/etc/nginx/sites-available"
+	sitesEnabledDir   = "/etc/nginx/sites-enabled"
+)
+
+func (r *NginxSiteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site"
+}
+
+func (r *NginxSiteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an NGINX server block under `sites-available`, optionally symlinking it into `sites-enabled` to enable it, following the Debian/Ubuntu NGINX layout.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the site, used as the filename in both `sites-available` and `sites-enabled`.",
+				Required:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the server block written to `available_path`.",
+				Required:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the site is symlinked into `sites-enabled`. Disabling a site leaves its file in `sites-available` but removes the symlink.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"available_path": schema.StringAttribute{
+				MarkdownDescription: "Path the site configuration is written to. Defaults to `/etc/nginx/sites-available/<name>`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the symlink created in `sites-enabled` when `enabled` is true. Defaults to `/etc/nginx/sites-enabled/<name>`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"reload_strategy": schema.StringAttribute{
+				MarkdownDescription: "How to apply the change once validated: `reload` (default) or `restart` nginx, or `none` to skip reloading entirely (e.g. when another `nginx_site`/`nginx_upstream` resource applied in the same apply will already trigger one).",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("reload"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("reload", "restart", "none"),
+				},
+			},
+			"test_before_reload": schema.BoolAttribute{
+				MarkdownDescription: "Run `nginx -t` after writing this site's files and before reloading/restarting nginx. Defaults to `true`; set to `false` to skip validation and reload unconditionally.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource ID, which is the available_path.",
+			},
+		},
+	}
+}
+
+func (r *NginxSiteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *NginxSiteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NginxSiteResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.AvailablePath.IsUnknown() || data.AvailablePath.ValueString() == "" {
+		data.AvailablePath = types.StringValue(fmt.Sprintf("%s/%s", sitesAvailableDir, data.Name.ValueString()))
+	}
+	if data.EnabledPath.IsUnknown() || data.EnabledPath.ValueString() == "" {
+		data.EnabledPath = types.StringValue(fmt.Sprintf("%s/%s", sitesEnabledDir, data.Name.ValueString()))
+	}
+
+	if err := r.apply(&data, true); err != nil {
+		resp.Diagnostics.AddError("Error applying site configuration", err.Error())
+		return
+	}
+
+	data.Id = data.AvailablePath
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NginxSiteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NginxSiteResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prior NginxSiteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contentChanged := !data.Content.Equal(prior.Content)
+
+	if err := r.apply(&data, contentChanged); err != nil {
+		resp.Diagnostics.AddError("Error applying site configuration", err.Error())
+		return
+	}
+
+	data.Id = data.AvailablePath
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apply brings enabledPath's symlink in line with Enabled, validates with
+// `nginx -t` (unless TestBeforeReload is false), and reloads (or restarts,
+// or skips reloading entirely) nginx per ReloadStrategy. It only rewrites
+// content to availablePath when contentChanged is true, so an Update that
+// only flips Enabled (a symlink-only change) skips re-uploading a file that
+// didn't change.
+func (r *NginxSiteResource) apply(data *NginxSiteResourceModel, contentChanged bool) error {
+	availablePath := data.AvailablePath.ValueString()
+	enabledPath := data.EnabledPath.ValueString()
+
+	if contentChanged {
+		if err := r.client.UploadFile(availablePath, []byte(data.Content.ValueString()), 0644); err != nil {
+			return fmt.Errorf("failed to upload site configuration to %s: %w", availablePath, err)
+		}
+	}
+
+	if err := r.setEnabled(enabledPath, availablePath, data.Enabled.ValueBool()); err != nil {
+		return err
+	}
+
+	if data.TestBeforeReload.ValueBool() {
+		if output, err := r.client.ValidateConfig(""); err != nil {
+			return fmt.Errorf("nginx configuration test failed for site %s: %s: %w", data.Name.ValueString(), output, err)
+		}
+	}
+
+	if err := r.client.Reload(data.ReloadStrategy.ValueString()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setEnabled symlinks enabledPath to availablePath when enabled is true, and
+// removes enabledPath (only if it is itself a symlink, so a same-named file
+// that isn't ours is left alone) when it is false.
+func (r *NginxSiteResource) setEnabled(enabledPath, availablePath string, enabled bool) error {
+	if enabled {
+		if _, err := r.client.RunCommand(fmt.Sprintf("sudo ln -sf %s %s", availablePath, enabledPath)); err != nil {
+			return fmt.Errorf("failed to symlink %s into %s: %w", availablePath, enabledPath, err)
+		}
+		return nil
+	}
+
+	if _, err := r.client.RunCommand(fmt.Sprintf("test -L %s && sudo rm -f %s; true", enabledPath, enabledPath)); err != nil {
+		return fmt.Errorf("failed to remove symlink %s: %w", enabledPath, err)
+	}
+	return nil
+}
+
+func (r *NginxSiteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NginxSiteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := r.client.DownloadFile(data.AvailablePath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading site configuration", fmt.Sprintf("Failed to read %s: %v", data.AvailablePath.ValueString(), err))
+		return
+	}
+	data.Content = types.StringValue(string(content))
+
+	_, err = r.client.RunCommand(fmt.Sprintf("test -L %s", data.EnabledPath.ValueString()))
+	data.Enabled = types.BoolValue(err == nil)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NginxSiteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NginxSiteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.RunCommand(fmt.Sprintf("sudo rm -f %s", data.EnabledPath.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Error disabling site", fmt.Sprintf("Failed to remove symlink %s: %v", data.EnabledPath.ValueString(), err))
+		return
+	}
+
+	if _, err := r.client.RunCommand(fmt.Sprintf("sudo rm -f %s", data.AvailablePath.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Error deleting site configuration", fmt.Sprintf("Failed to delete %s: %v", data.AvailablePath.ValueString(), err))
+		return
+	}
+
+	if data.TestBeforeReload.ValueBool() {
+		if output, err := r.client.ValidateConfig(""); err != nil {
+			resp.Diagnostics.AddError("Error validating nginx configuration", fmt.Sprintf("nginx configuration test failed after deleting site %s: %s: %v", data.Name.ValueString(), output, err))
+			return
+		}
+	}
+
+	if err := r.client.Reload(data.ReloadStrategy.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error reloading nginx", fmt.Sprintf("Failed to reload nginx after deleting site %s: %v", data.Name.ValueString(), err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *NginxSiteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
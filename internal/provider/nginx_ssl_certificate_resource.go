@@ -0,0 +1,311 @@
+package nginx
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NginxSSLCertificateResource{}
+var _ resource.ResourceWithImportState = &NginxSSLCertificateResource{}
+
+func NewNginxSSLCertificateResource() resource.Resource {
+	return &NginxSSLCertificateResource{}
+}
+
+// NginxSSLCertificateResource defines the resource implementation.
+type NginxSSLCertificateResource struct {
+	client *Client
+}
+
+// NginxSSLCertificateResourceModel describes the resource data model.
+type NginxSSLCertificateResourceModel struct {
+	Name               types.String   `tfsdk:"name"`
+	CertPEM            types.String   `tfsdk:"cert_pem"`
+	KeyPEM             types.String   `tfsdk:"key_pem"`
+	CertPath           types.String   `tfsdk:"cert_path"`
+	KeyPath            types.String   `tfsdk:"key_path"`
+	RenewThresholdDays types.Int64    `tfsdk:"renew_threshold_days"`
+	CertSHA256         types.String   `tfsdk:"cert_sha256"`
+	KeySHA256          types.String   `tfsdk:"key_sha256"`
+	NotBefore          types.String   `tfsdk:"not_before"`
+	NotAfter           types.String   `tfsdk:"not_after"`
+	Subject            types.String   `tfsdk:"subject"`
+	DNSNames           []types.String `tfsdk:"dns_names"`
+	Serial             types.String   `tfsdk:"serial"`
+	Id                 types.String   `tfsdk:"id"`
+}
+
+// sslDir is where cert_path/key_path default to when left unset.
+const sslDir = "/etc/nginx/ssl"
+
+func (r *NginxSSLCertificateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssl_certificate"
+}
+
+func (r *NginxSSLCertificateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a TLS certificate/key pair on the NGINX host: uploads both files with `root:root` ownership and locked-down permissions, tracks SHA-256 fingerprints so Terraform detects rotation, and surfaces the parsed certificate's validity window for expiry monitoring.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name used to derive default `cert_path`/`key_path` values.",
+				Required:            true,
+			},
+			"cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded certificate chain, leaf first.",
+				Required:            true,
+			},
+			"key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded private key matching `cert_pem`.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"cert_path": schema.StringAttribute{
+				MarkdownDescription: "Path the certificate chain is written to, mode `0644`. Defaults to `/etc/nginx/ssl/<name>.crt`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_path": schema.StringAttribute{
+				MarkdownDescription: "Path the private key is written to, mode `0600`. Defaults to `/etc/nginx/ssl/<name>.key`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"renew_threshold_days": schema.Int64Attribute{
+				MarkdownDescription: "Read emits a warning diagnostic once the certificate's `not_after` is within this many days. Defaults to 30.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(30),
+			},
+			"cert_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of `cert_pem` as deployed, used to detect rotation.",
+				Computed:            true,
+			},
+			"key_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of `key_pem` as deployed, used to detect rotation.",
+				Computed:            true,
+			},
+			"not_before": schema.StringAttribute{
+				MarkdownDescription: "Certificate validity start, RFC 3339.",
+				Computed:            true,
+			},
+			"not_after": schema.StringAttribute{
+				MarkdownDescription: "Certificate validity end, RFC 3339.",
+				Computed:            true,
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "Certificate subject distinguished name.",
+				Computed:            true,
+			},
+			"dns_names": schema.ListAttribute{
+				MarkdownDescription: "Subject Alternative Name DNS entries on the certificate.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"serial": schema.StringAttribute{
+				MarkdownDescription: "Certificate serial number, decimal.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource ID, which is the cert_path.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *NginxSSLCertificateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *NginxSSLCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NginxSSLCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.CertPath.IsUnknown() || data.CertPath.ValueString() == "" {
+		data.CertPath = types.StringValue(fmt.Sprintf("%s/%s.crt", sslDir, data.Name.ValueString()))
+	}
+	if data.KeyPath.IsUnknown() || data.KeyPath.ValueString() == "" {
+		data.KeyPath = types.StringValue(fmt.Sprintf("%s/%s.key", sslDir, data.Name.ValueString()))
+	}
+
+	if err := r.apply(&data); err != nil {
+		resp.Diagnostics.AddError("Error applying SSL certificate", err.Error())
+		return
+	}
+
+	data.Id = data.CertPath
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NginxSSLCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NginxSSLCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(&data); err != nil {
+		resp.Diagnostics.AddError("Error applying SSL certificate", err.Error())
+		return
+	}
+
+	data.Id = data.CertPath
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apply parses cert_pem to populate the computed certificate attributes,
+// uploads both files, and fixes up ownership/mode with a follow-up sudo
+// command since SFTP uploads land owned by the SSH user.
+func (r *NginxSSLCertificateResource) apply(data *NginxSSLCertificateResourceModel) error {
+	cert, err := parseLeafCertificate(data.CertPEM.ValueString())
+	if err != nil {
+		return fmt.Errorf("cert_pem is not a valid certificate: %w", err)
+	}
+	populateCertAttributes(data, cert)
+
+	certPath := data.CertPath.ValueString()
+	keyPath := data.KeyPath.ValueString()
+
+	if err := r.client.UploadFile(certPath, []byte(data.CertPEM.ValueString()), 0644); err != nil {
+		return fmt.Errorf("failed to upload certificate to %s: %w", certPath, err)
+	}
+	if _, err := r.client.RunCommand(fmt.Sprintf("sudo chown root:root %s && sudo chmod 0644 %s", certPath, certPath)); err != nil {
+		return fmt.Errorf("failed to set ownership/mode on %s: %w", certPath, err)
+	}
+
+	if err := r.client.UploadFile(keyPath, []byte(data.KeyPEM.ValueString()), 0600); err != nil {
+		return fmt.Errorf("failed to upload private key to %s: %w", keyPath, err)
+	}
+	if _, err := r.client.RunCommand(fmt.Sprintf("sudo chown root:root %s && sudo chmod 0600 %s", keyPath, keyPath)); err != nil {
+		return fmt.Errorf("failed to set ownership/mode on %s: %w", keyPath, err)
+	}
+
+	data.CertSHA256 = types.StringValue(hashContent(data.CertPEM.ValueString()))
+	data.KeySHA256 = types.StringValue(hashContent(data.KeyPEM.ValueString()))
+
+	return nil
+}
+
+// parseLeafCertificate decodes the first PEM block of a certificate (chain)
+// and parses it as an X.509 certificate.
+func parseLeafCertificate(certPEM string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func populateCertAttributes(data *NginxSSLCertificateResourceModel, cert *x509.Certificate) {
+	data.NotBefore = types.StringValue(cert.NotBefore.UTC().Format(time.RFC3339))
+	data.NotAfter = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+	data.Subject = types.StringValue(cert.Subject.String())
+	data.Serial = types.StringValue(cert.SerialNumber.String())
+
+	data.DNSNames = nil
+	for _, name := range cert.DNSNames {
+		data.DNSNames = append(data.DNSNames, types.StringValue(name))
+	}
+}
+
+func (r *NginxSSLCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NginxSSLCertificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certContent, err := r.client.DownloadFile(data.CertPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading certificate", fmt.Sprintf("Failed to read %s: %v", data.CertPath.ValueString(), err))
+		return
+	}
+	keyContent, err := r.client.DownloadFile(data.KeyPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading private key", fmt.Sprintf("Failed to read %s: %v", data.KeyPath.ValueString(), err))
+		return
+	}
+
+	cert, err := parseLeafCertificate(string(certContent))
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing certificate", fmt.Sprintf("Failed to parse deployed certificate at %s: %v", data.CertPath.ValueString(), err))
+		return
+	}
+
+	data.CertPEM = types.StringValue(string(certContent))
+	data.KeyPEM = types.StringValue(string(keyContent))
+	data.CertSHA256 = types.StringValue(hashContent(string(certContent)))
+	data.KeySHA256 = types.StringValue(hashContent(string(keyContent)))
+	populateCertAttributes(&data, cert)
+
+	threshold := time.Duration(data.RenewThresholdDays.ValueInt64()) * 24 * time.Hour
+	if time.Until(cert.NotAfter) <= threshold {
+		resp.Diagnostics.AddWarning(
+			"Certificate nearing expiry",
+			fmt.Sprintf("Certificate %s (subject %s) expires at %s, within the %d day renew_threshold_days.",
+				data.CertPath.ValueString(), data.Subject.ValueString(), data.NotAfter.ValueString(), data.RenewThresholdDays.ValueInt64()),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NginxSSLCertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NginxSSLCertificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyPath := data.KeyPath.ValueString()
+	shredCommand := fmt.Sprintf(
+		"if command -v shred >/dev/null 2>&1; then sudo shred -u %s; else sudo sh -c \"dd if=/dev/zero of='%s' bs=1 count=\\$(stat -c%%s '%s') 2>/dev/null; rm -f '%s'\"; fi",
+		keyPath, keyPath, keyPath, keyPath,
+	)
+	if _, err := r.client.RunCommand(shredCommand); err != nil {
+		resp.Diagnostics.AddError("Error shredding private key", fmt.Sprintf("Failed to shred %s: %v", keyPath, err))
+		return
+	}
+
+	if _, err := r.client.RunCommand(fmt.Sprintf("sudo rm -f %s", data.CertPath.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Error deleting certificate", fmt.Sprintf("Failed to delete %s: %v", data.CertPath.ValueString(), err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *NginxSSLCertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,50 @@
+package nginx
+
+import "testing"
+
+// testLeafCertPEM is a self-signed ECDSA certificate for CN=example.com with
+// SANs example.com and www.example.com, generated solely for this test.
+const testLeafCertPEM = `-----BEGIN CERTIFICATE-----
+MIIBRDCB7KADAgECAgEBMAoGCCqGSM49BAMCMBYxFDASBgNVBAMTC2V4YW1wbGUu
+Y29tMB4XDTI2MDczMDE1NTkxNVoXDTI2MDczMTE1NTkxNVowFjEUMBIGA1UEAxML
+ZXhhbXBsZS5jb20wWTATBgcqhkjOPQIBBggqhkjOPQMBBwNCAASR7d3PfqrYZt4A
+HuP1Y+9zqFNqL6GLhkxeg/RdpIjSaiCId3wPaIzOQdm52SOdWNRiXS7eo+gB4k4i
+O0MeRNf8oyswKTAnBgNVHREEIDAeggtleGFtcGxlLmNvbYIPd3d3LmV4YW1wbGUu
+Y29tMAoGCCqGSM49BAMCA0cAMEQCIFCQNeic/ZfaX+Hkq2iyaLzR70cXlSbhSSYl
+DRJ6WjAMAiBuXMrp30kYna+ycdVfp89pORGVkHEpb0cDZ1Uxm0pvpg==
+-----END CERTIFICATE-----
+`
+
+func TestParseLeafCertificate(t *testing.T) {
+	cert, err := parseLeafCertificate(testLeafCertPEM)
+	if err != nil {
+		t.Fatalf("parseLeafCertificate() returned error: %v", err)
+	}
+
+	if cert.Subject.CommonName != "example.com" {
+		t.Errorf("Subject.CommonName = %q, want %q", cert.Subject.CommonName, "example.com")
+	}
+
+	want := map[string]bool{"example.com": true, "www.example.com": true}
+	if len(cert.DNSNames) != len(want) {
+		t.Fatalf("DNSNames = %v, want %d entries", cert.DNSNames, len(want))
+	}
+	for _, name := range cert.DNSNames {
+		if !want[name] {
+			t.Errorf("unexpected DNS SAN %q", name)
+		}
+	}
+}
+
+func TestParseLeafCertificate_NoPEMBlock(t *testing.T) {
+	if _, err := parseLeafCertificate("not a pem file"); err == nil {
+		t.Error("parseLeafCertificate() with no PEM block: expected error, got nil")
+	}
+}
+
+func TestParseLeafCertificate_InvalidDER(t *testing.T) {
+	invalid := "-----BEGIN CERTIFICATE-----\nYWJj\n-----END CERTIFICATE-----\n"
+	if _, err := parseLeafCertificate(invalid); err == nil {
+		t.Error("parseLeafCertificate() with invalid DER content: expected error, got nil")
+	}
+}
@@ -0,0 +1,450 @@
+package nginx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NginxUpstreamResource{}
+var _ resource.ResourceWithImportState = &NginxUpstreamResource{}
+
+func NewNginxUpstreamResource() resource.Resource {
+	return &NginxUpstreamResource{}
+}
+
+// NginxUpstreamResource defines the resource implementation.
+type NginxUpstreamResource struct {
+	client *Client
+}
+
+// NginxUpstreamResourceModel describes the resource data model.
+type NginxUpstreamResourceModel struct {
+	Name        types.String               `tfsdk:"name"`
+	Path        types.String               `tfsdk:"path"`
+	Method      types.String               `tfsdk:"method"`
+	HashKey     types.String               `tfsdk:"hash_key"`
+	Keepalive   types.Int64                `tfsdk:"keepalive"`
+	Server      []NginxUpstreamServerModel `tfsdk:"server"`
+	HealthCheck *NginxHealthCheckModel     `tfsdk:"health_check"`
+	Id          types.String               `tfsdk:"id"`
+}
+
+// NginxUpstreamServerModel describes a single `server` entry in the upstream block.
+type NginxUpstreamServerModel struct {
+	Address     types.String `tfsdk:"address"`
+	Weight      types.Int64  `tfsdk:"weight"`
+	MaxFails    types.Int64  `tfsdk:"max_fails"`
+	FailTimeout types.String `tfsdk:"fail_timeout"`
+	Backup      types.Bool   `tfsdk:"backup"`
+}
+
+// NginxHealthCheckModel describes the optional `health_check` block.
+type NginxHealthCheckModel struct {
+	Interval types.String `tfsdk:"interval"`
+	Fails    types.Int64  `tfsdk:"fails"`
+	Passes   types.Int64  `tfsdk:"passes"`
+	URI      types.String `tfsdk:"uri"`
+}
+
+func (r *NginxUpstreamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_upstream"
+}
+
+func (r *NginxUpstreamResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an NGINX `upstream` block, including its backend servers, load-balancing method, and health checks.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the upstream group, used in `upstream <name> { ... }`.",
+				Required:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path to the file the upstream block is written to. Defaults to `/etc/nginx/conf.d/<name>_upstream.conf`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"method": schema.StringAttribute{
+				MarkdownDescription: "Load-balancing method: `round_robin` (default), `least_conn`, `ip_hash`, or `hash`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("round_robin", "least_conn", "ip_hash", "hash"),
+				},
+			},
+			"hash_key": schema.StringAttribute{
+				MarkdownDescription: "Key expression for the `hash` method, e.g. `$remote_addr` or `$request_uri`. Only used when `method = \"hash\"`; defaults to `$remote_addr`.",
+				Optional:            true,
+			},
+			"keepalive": schema.Int64Attribute{
+				MarkdownDescription: "Number of idle keepalive connections to backend servers to cache per worker.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource ID, which is the path to the rendered upstream file.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"server": schema.ListNestedBlock{
+				MarkdownDescription: "A backend server participating in the upstream group.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							MarkdownDescription: "Backend address, e.g. `10.0.0.1:8080`.",
+							Required:            true,
+						},
+						"weight": schema.Int64Attribute{
+							MarkdownDescription: "Relative weight used by `round_robin`/`least_conn`. Defaults to 1.",
+							Optional:            true,
+						},
+						"max_fails": schema.Int64Attribute{
+							MarkdownDescription: "Number of unsuccessful attempts before the server is considered unavailable.",
+							Optional:            true,
+						},
+						"fail_timeout": schema.StringAttribute{
+							MarkdownDescription: "Time during which `max_fails` is counted and the server is marked unavailable, e.g. `10s`.",
+							Optional:            true,
+						},
+						"backup": schema.BoolAttribute{
+							MarkdownDescription: "Marks the server as a backup, only used when primary servers are unavailable.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+			"health_check": schema.SingleNestedBlock{
+				MarkdownDescription: "Active health-check configuration for the upstream group.",
+				Attributes: map[string]schema.Attribute{
+					"interval": schema.StringAttribute{
+						MarkdownDescription: "How often to run the health check, e.g. `5s`.",
+						Optional:            true,
+					},
+					"fails": schema.Int64Attribute{
+						MarkdownDescription: "Consecutive failed checks before a server is marked unhealthy.",
+						Optional:            true,
+					},
+					"passes": schema.Int64Attribute{
+						MarkdownDescription: "Consecutive successful checks before a server is marked healthy again.",
+						Optional:            true,
+					},
+					"uri": schema.StringAttribute{
+						MarkdownDescription: "URI requested against the backend for the health check.",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *NginxUpstreamResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *NginxUpstreamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NginxUpstreamResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Path.IsUnknown() || data.Path.ValueString() == "" {
+		data.Path = types.StringValue(fmt.Sprintf("/etc/nginx/conf.d/%s_upstream.conf", data.Name.ValueString()))
+	}
+	if data.Method.IsUnknown() || data.Method.ValueString() == "" {
+		data.Method = types.StringValue("round_robin")
+	}
+
+	if err := r.apply(&data); err != nil {
+		resp.Diagnostics.AddError("Error applying upstream configuration", err.Error())
+		return
+	}
+
+	data.Id = data.Path
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NginxUpstreamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NginxUpstreamResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(&data); err != nil {
+		resp.Diagnostics.AddError("Error applying upstream configuration", err.Error())
+		return
+	}
+
+	data.Id = data.Path
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apply renders the upstream block and writes it to the remote host over
+// SFTP, backing up any file already at the path first. If `nginx -t` then
+// rejects the change, the backup (or the file's prior absence) is restored
+// so a failed apply never leaves a broken upstream file in place; only once
+// validation passes does it reload.
+func (r *NginxUpstreamResource) apply(data *NginxUpstreamResourceModel) error {
+	path := data.Path.ValueString()
+	content := renderUpstreamBlock(data)
+
+	backup, downloadErr := r.client.DownloadFile(path)
+	existed := downloadErr == nil
+
+	if err := r.client.UploadFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to upload upstream configuration to %s: %w", path, err)
+	}
+
+	if output, err := r.client.RunCommand("nginx -t"); err != nil {
+		if existed {
+			if restoreErr := r.client.UploadFile(path, backup, 0644); restoreErr != nil {
+				return fmt.Errorf("nginx -t failed (%s) and restoring the previous content at %s also failed: %w", output, path, restoreErr)
+			}
+		} else if _, rmErr := r.client.RunCommand(fmt.Sprintf("rm -f %s", path)); rmErr != nil {
+			return fmt.Errorf("nginx -t failed (%s) and removing the invalid file at %s also failed: %w", output, path, rmErr)
+		}
+		return fmt.Errorf("nginx configuration test failed after writing %s, previous state restored: %s: %w", path, output, err)
+	}
+
+	if _, err := r.client.RunCommand("sudo systemctl reload nginx"); err != nil {
+		return fmt.Errorf("failed to reload nginx: %w", err)
+	}
+
+	return nil
+}
+
+// renderUpstreamBlock builds the textual `upstream { ... }` representation of data.
+func renderUpstreamBlock(data *NginxUpstreamResourceModel) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "upstream %s {\n", data.Name.ValueString())
+
+	method := data.Method.ValueString()
+	switch {
+	case method == "hash":
+		key := data.HashKey.ValueString()
+		if key == "" {
+			key = "$remote_addr"
+		}
+		fmt.Fprintf(&b, "\thash %s;\n", key)
+	case method != "" && method != "round_robin":
+		fmt.Fprintf(&b, "\t%s;\n", method)
+	}
+
+	for _, server := range data.Server {
+		b.WriteString("\tserver " + server.Address.ValueString())
+		if server.Weight.ValueInt64() != 0 {
+			fmt.Fprintf(&b, " weight=%d", server.Weight.ValueInt64())
+		}
+		if server.MaxFails.ValueInt64() != 0 {
+			fmt.Fprintf(&b, " max_fails=%d", server.MaxFails.ValueInt64())
+		}
+		if server.FailTimeout.ValueString() != "" {
+			fmt.Fprintf(&b, " fail_timeout=%s", server.FailTimeout.ValueString())
+		}
+		if server.Backup.ValueBool() {
+			b.WriteString(" backup")
+		}
+		b.WriteString(";\n")
+	}
+
+	if data.Keepalive.ValueInt64() != 0 {
+		fmt.Fprintf(&b, "\tkeepalive %d;\n", data.Keepalive.ValueInt64())
+	}
+
+	if data.HealthCheck != nil {
+		b.WriteString("\thealth_check")
+		if uri := data.HealthCheck.URI.ValueString(); uri != "" {
+			fmt.Fprintf(&b, " uri=%s", uri)
+		}
+		if fails := data.HealthCheck.Fails.ValueInt64(); fails != 0 {
+			fmt.Fprintf(&b, " fails=%d", fails)
+		}
+		if passes := data.HealthCheck.Passes.ValueInt64(); passes != 0 {
+			fmt.Fprintf(&b, " passes=%d", passes)
+		}
+		if interval := data.HealthCheck.Interval.ValueString(); interval != "" {
+			fmt.Fprintf(&b, " interval=%s", interval)
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (r *NginxUpstreamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NginxUpstreamResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stdout, err := r.client.RunCommand(fmt.Sprintf("cat %s", data.Path.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading upstream configuration", fmt.Sprintf("Failed to read configuration at %s: %v", data.Path.ValueString(), err))
+		return
+	}
+
+	parsed, err := parseUpstreamBlock(stdout)
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing upstream configuration", fmt.Sprintf("Failed to parse configuration at %s: %v", data.Path.ValueString(), err))
+		return
+	}
+
+	data.Name = parsed.Name
+	data.Method = parsed.Method
+	data.HashKey = parsed.HashKey
+	data.Keepalive = parsed.Keepalive
+	data.Server = parsed.Server
+	data.HealthCheck = parsed.HealthCheck
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseUpstreamBlock parses a deployed `upstream { ... }` file back into the
+// resource model so that drift from manual edits is detected.
+func parseUpstreamBlock(content string) (*NginxUpstreamResourceModel, error) {
+	data := &NginxUpstreamResourceModel{
+		Method: types.StringValue("round_robin"),
+	}
+
+	lines := strings.Split(content, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		line = strings.TrimSuffix(line, ";")
+
+		switch {
+		case strings.HasPrefix(line, "upstream "):
+			fields := strings.Fields(line)
+			if len(fields) >= 2 {
+				data.Name = types.StringValue(fields[1])
+			}
+		case line == "least_conn", line == "ip_hash":
+			data.Method = types.StringValue(line)
+		case line == "hash", strings.HasPrefix(line, "hash "):
+			data.Method = types.StringValue("hash")
+			data.HashKey = types.StringValue(strings.TrimSpace(strings.TrimPrefix(line, "hash")))
+		case strings.HasPrefix(line, "server "):
+			server := parseUpstreamServer(strings.TrimPrefix(line, "server "))
+			data.Server = append(data.Server, server)
+		case strings.HasPrefix(line, "keepalive "):
+			fields := strings.Fields(line)
+			if len(fields) >= 2 {
+				var keepalive int64
+				if _, err := fmt.Sscanf(fields[1], "%d", &keepalive); err == nil {
+					data.Keepalive = types.Int64Value(keepalive)
+				}
+			}
+		case strings.HasPrefix(line, "health_check"):
+			data.HealthCheck = parseHealthCheck(strings.TrimPrefix(line, "health_check"))
+		}
+	}
+
+	if data.Name.ValueString() == "" {
+		return nil, fmt.Errorf("no upstream block found")
+	}
+
+	return data, nil
+}
+
+func parseUpstreamServer(fields string) NginxUpstreamServerModel {
+	server := NginxUpstreamServerModel{Backup: types.BoolValue(false)}
+	for i, field := range strings.Fields(fields) {
+		if i == 0 {
+			server.Address = types.StringValue(field)
+			continue
+		}
+		switch {
+		case field == "backup":
+			server.Backup = types.BoolValue(true)
+		case strings.HasPrefix(field, "weight="):
+			var weight int64
+			fmt.Sscanf(strings.TrimPrefix(field, "weight="), "%d", &weight)
+			server.Weight = types.Int64Value(weight)
+		case strings.HasPrefix(field, "max_fails="):
+			var maxFails int64
+			fmt.Sscanf(strings.TrimPrefix(field, "max_fails="), "%d", &maxFails)
+			server.MaxFails = types.Int64Value(maxFails)
+		case strings.HasPrefix(field, "fail_timeout="):
+			server.FailTimeout = types.StringValue(strings.TrimPrefix(field, "fail_timeout="))
+		}
+	}
+	return server
+}
+
+func parseHealthCheck(fields string) *NginxHealthCheckModel {
+	hc := &NginxHealthCheckModel{}
+	for _, field := range strings.Fields(fields) {
+		switch {
+		case strings.HasPrefix(field, "uri="):
+			hc.URI = types.StringValue(strings.TrimPrefix(field, "uri="))
+		case strings.HasPrefix(field, "fails="):
+			var fails int64
+			fmt.Sscanf(strings.TrimPrefix(field, "fails="), "%d", &fails)
+			hc.Fails = types.Int64Value(fails)
+		case strings.HasPrefix(field, "passes="):
+			var passes int64
+			fmt.Sscanf(strings.TrimPrefix(field, "passes="), "%d", &passes)
+			hc.Passes = types.Int64Value(passes)
+		case strings.HasPrefix(field, "interval="):
+			hc.Interval = types.StringValue(strings.TrimPrefix(field, "interval="))
+		}
+	}
+	return hc
+}
+
+func (r *NginxUpstreamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NginxUpstreamResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.RunCommand(fmt.Sprintf("sudo rm -f %s", data.Path.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Error deleting upstream configuration", fmt.Sprintf("Failed to delete configuration at %s: %v", data.Path.ValueString(), err))
+		return
+	}
+
+	if _, err := r.client.RunCommand("nginx -t && sudo systemctl reload nginx"); err != nil {
+		resp.Diagnostics.AddError("Error reloading nginx", fmt.Sprintf("Failed to reload nginx after deleting %s: %v", data.Path.ValueString(), err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *NginxUpstreamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
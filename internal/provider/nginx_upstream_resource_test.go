@@ -0,0 +1,145 @@
+package nginx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRenderUpstreamBlock(t *testing.T) {
+	data := &NginxUpstreamResourceModel{
+		Name:      types.StringValue("backend"),
+		Method:    types.StringValue("least_conn"),
+		Keepalive: types.Int64Value(32),
+		Server: []NginxUpstreamServerModel{
+			{
+				Address:     types.StringValue("10.0.0.1:8080"),
+				Weight:      types.Int64Value(5),
+				MaxFails:    types.Int64Value(3),
+				FailTimeout: types.StringValue("30s"),
+			},
+			{
+				Address: types.StringValue("10.0.0.2:8080"),
+				Backup:  types.BoolValue(true),
+			},
+		},
+		HealthCheck: &NginxHealthCheckModel{
+			URI:      types.StringValue("/healthz"),
+			Fails:    types.Int64Value(2),
+			Passes:   types.Int64Value(1),
+			Interval: types.StringValue("5s"),
+		},
+	}
+
+	got := renderUpstreamBlock(data)
+	want := "upstream backend {\n" +
+		"\tleast_conn;\n" +
+		"\tserver 10.0.0.1:8080 weight=5 max_fails=3 fail_timeout=30s;\n" +
+		"\tserver 10.0.0.2:8080 backup;\n" +
+		"\tkeepalive 32;\n" +
+		"\thealth_check uri=/healthz fails=2 passes=1 interval=5s;\n" +
+		"}\n"
+
+	if got != want {
+		t.Errorf("renderUpstreamBlock() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderUpstreamBlock_HashMethod(t *testing.T) {
+	data := &NginxUpstreamResourceModel{
+		Name:    types.StringValue("backend"),
+		Method:  types.StringValue("hash"),
+		HashKey: types.StringValue("$uri"),
+		Server: []NginxUpstreamServerModel{
+			{Address: types.StringValue("10.0.0.1:8080")},
+		},
+	}
+
+	got := renderUpstreamBlock(data)
+	want := "upstream backend {\n\thash $uri;\n\tserver 10.0.0.1:8080;\n}\n"
+
+	if got != want {
+		t.Errorf("renderUpstreamBlock() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderUpstreamBlock_HashMethodDefaultsKey(t *testing.T) {
+	data := &NginxUpstreamResourceModel{
+		Name:   types.StringValue("backend"),
+		Method: types.StringValue("hash"),
+		Server: []NginxUpstreamServerModel{
+			{Address: types.StringValue("10.0.0.1:8080")},
+		},
+	}
+
+	got := renderUpstreamBlock(data)
+	want := "upstream backend {\n\thash $remote_addr;\n\tserver 10.0.0.1:8080;\n}\n"
+
+	if got != want {
+		t.Errorf("renderUpstreamBlock() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParseUpstreamBlock_RoundTrip(t *testing.T) {
+	content := "upstream backend {\n" +
+		"\tleast_conn;\n" +
+		"\tserver 10.0.0.1:8080 weight=5 max_fails=3 fail_timeout=30s;\n" +
+		"\tserver 10.0.0.2:8080 backup;\n" +
+		"\tkeepalive 32;\n" +
+		"\thealth_check uri=/healthz fails=2 passes=1 interval=5s;\n" +
+		"}\n"
+
+	data, err := parseUpstreamBlock(content)
+	if err != nil {
+		t.Fatalf("parseUpstreamBlock() returned error: %v", err)
+	}
+
+	if data.Name.ValueString() != "backend" {
+		t.Errorf("Name = %q, want %q", data.Name.ValueString(), "backend")
+	}
+	if data.Method.ValueString() != "least_conn" {
+		t.Errorf("Method = %q, want %q", data.Method.ValueString(), "least_conn")
+	}
+	if data.Keepalive.ValueInt64() != 32 {
+		t.Errorf("Keepalive = %d, want 32", data.Keepalive.ValueInt64())
+	}
+	if len(data.Server) != 2 {
+		t.Fatalf("len(Server) = %d, want 2", len(data.Server))
+	}
+	if data.Server[0].Address.ValueString() != "10.0.0.1:8080" || data.Server[0].Weight.ValueInt64() != 5 ||
+		data.Server[0].MaxFails.ValueInt64() != 3 || data.Server[0].FailTimeout.ValueString() != "30s" {
+		t.Errorf("Server[0] = %+v, unexpected values", data.Server[0])
+	}
+	if !data.Server[1].Backup.ValueBool() {
+		t.Errorf("Server[1].Backup = false, want true")
+	}
+	if data.HealthCheck == nil {
+		t.Fatal("HealthCheck is nil, want populated")
+	}
+	if data.HealthCheck.URI.ValueString() != "/healthz" || data.HealthCheck.Fails.ValueInt64() != 2 ||
+		data.HealthCheck.Passes.ValueInt64() != 1 || data.HealthCheck.Interval.ValueString() != "5s" {
+		t.Errorf("HealthCheck = %+v, unexpected values", data.HealthCheck)
+	}
+}
+
+func TestParseUpstreamBlock_HashMethod(t *testing.T) {
+	content := "upstream backend {\n\thash $uri consistent;\n\tserver 10.0.0.1:8080;\n}\n"
+
+	data, err := parseUpstreamBlock(content)
+	if err != nil {
+		t.Fatalf("parseUpstreamBlock() returned error: %v", err)
+	}
+
+	if data.Method.ValueString() != "hash" {
+		t.Errorf("Method = %q, want %q", data.Method.ValueString(), "hash")
+	}
+	if data.HashKey.ValueString() != "$uri consistent" {
+		t.Errorf("HashKey = %q, want %q", data.HashKey.ValueString(), "$uri consistent")
+	}
+}
+
+func TestParseUpstreamBlock_MissingName(t *testing.T) {
+	if _, err := parseUpstreamBlock("\tserver 10.0.0.1:8080;\n"); err == nil {
+		t.Error("parseUpstreamBlock() with no upstream line: expected error, got nil")
+	}
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"golang.org/x/crypto/ssh"
 )
 
 type NginxProvider struct {
@@ -18,19 +19,36 @@ type NginxProvider struct {
 
 // NginxProviderModel describes the provider data model.
 type NginxProviderModel struct {
-	Host     types.String `tfsdk:"host"`
-	User     types.String `tfsdk:"user"`
-	Password types.String `tfsdk:"password"`
+	Host                 types.String       `tfsdk:"host"`
+	User                 types.String       `tfsdk:"user"`
+	Password             types.String       `tfsdk:"password"`
+	PrivateKey           types.String       `tfsdk:"private_key"`
+	PrivateKeyPath       types.String       `tfsdk:"private_key_path"`
+	PrivateKeyPassphrase types.String       `tfsdk:"private_key_passphrase"`
+	UseSSHAgent          types.Bool         `tfsdk:"use_ssh_agent"`
+	KnownHosts           types.String       `tfsdk:"known_hosts"`
+	Bastion              *NginxBastionModel `tfsdk:"bastion"`
+	NginxConfPath        types.String       `tfsdk:"nginx_conf_path"`
 }
 
-type Client struct {
-	SSHClient *ssh.Client
+// NginxBastionModel describes the optional `bastion` block used to tunnel
+// through a jump host to reach the NGINX server.
+type NginxBastionModel struct {
+	Host                 types.String `tfsdk:"host"`
+	User                 types.String `tfsdk:"user"`
+	Password             types.String `tfsdk:"password"`
+	PrivateKey           types.String `tfsdk:"private_key"`
+	PrivateKeyPath       types.String `tfsdk:"private_key_path"`
+	PrivateKeyPassphrase types.String `tfsdk:"private_key_passphrase"`
 }
 
+// defaultNginxConfPath is used for `nginx -t -c <path>` validation when the
+// provider does not set nginx_conf_path.
+const defaultNginxConfPath = "/etc/nginx/nginx.conf"
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
-		return nil
-
+		return &NginxProvider{version: version}
 	}
 }
 
@@ -41,21 +59,81 @@ func (p *NginxProvider) Metadata(ctx context.Context, req provider.MetadataReque
 
 func (p *NginxProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Provider for managing NGINX configurations via SSH.",
+		MarkdownDescription: "Provider for managing NGINX configurations via SSH. `host`, `user`, and `password` fall back to the `NGINX_SSH_HOST`, `NGINX_SSH_USER`, and `NGINX_SSH_PASSWORD` environment variables when unset, so credentials don't have to live in HCL.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "The hostname or IP address of the NGINX server.",
-				Required:            true,
+				MarkdownDescription: "The hostname or IP address of the NGINX server. Falls back to `NGINX_SSH_HOST`.",
+				Optional:            true,
 			},
 			"user": schema.StringAttribute{
-				MarkdownDescription: "The SSH username to connect to the NGINX server.",
-				Required:            true,
+				MarkdownDescription: "The SSH username to connect to the NGINX server. Falls back to `NGINX_SSH_USER`.",
+				Optional:            true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "The SSH password to connect to the NGINX server.",
-				Required:            true,
+				MarkdownDescription: "The SSH password to connect to the NGINX server. Falls back to `NGINX_SSH_PASSWORD`.",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded SSH private key used to authenticate, as an alternative to `password`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"private_key_path": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM-encoded SSH private key file on the machine running Terraform, as an alternative to `private_key`.",
+				Optional:            true,
+			},
+			"private_key_passphrase": schema.StringAttribute{
+				MarkdownDescription: "Passphrase protecting `private_key`/`private_key_path`, if any.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"use_ssh_agent": schema.BoolAttribute{
+				MarkdownDescription: "Authenticate using keys loaded in the SSH agent reachable via `SSH_AUTH_SOCK`.",
+				Optional:            true,
+			},
+			"known_hosts": schema.StringAttribute{
+				MarkdownDescription: "A `known_hosts` file path, or its content inline, used to verify the server's host key. Strongly recommended: omitting this disables host key verification.",
+				Optional:            true,
+			},
+			"nginx_conf_path": schema.StringAttribute{
+				MarkdownDescription: "Path to the main nginx.conf on the remote host, passed to `nginx -t -c` when the `nginx_nginx_conf` and `nginx_site` resources validate a change (`nginx_upstream` runs a plain `nginx -t`, and `nginx_configuration` validates its own `root_file`). Defaults to `/etc/nginx/nginx.conf`.",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"bastion": schema.SingleNestedBlock{
+				MarkdownDescription: "An SSH jump host to tunnel through to reach `host`.",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						MarkdownDescription: "Hostname or IP address of the bastion host.",
+						Required:            true,
+					},
+					"user": schema.StringAttribute{
+						MarkdownDescription: "SSH username on the bastion host.",
+						Required:            true,
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "SSH password for the bastion host.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"private_key": schema.StringAttribute{
+						MarkdownDescription: "PEM-encoded SSH private key for the bastion host.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"private_key_path": schema.StringAttribute{
+						MarkdownDescription: "Path to a PEM-encoded SSH private key file for the bastion host.",
+						Optional:            true,
+					},
+					"private_key_passphrase": schema.StringAttribute{
+						MarkdownDescription: "Passphrase protecting the bastion private key, if any.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -67,63 +145,80 @@ func (p *NginxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	host := data.Host.ValueString()
-	user := data.User.ValueString()
-	password := data.Password.ValueString()
-
-	log.Printf("[DEBUG] Initializing client for Host: %s, User: %s", host, user)
+	host := stringOrEnv(data.Host, "NGINX_SSH_HOST")
+	user := stringOrEnv(data.User, "NGINX_SSH_USER")
+	password := stringOrEnv(data.Password, "NGINX_SSH_PASSWORD")
 
-	client, err := NewClient(host, user, password)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Initialization Error", fmt.Sprintf("Unable to initialize SSH client: %s", err))
+	if host == "" {
+		resp.Diagnostics.AddError("Missing Host", "host must be set, either in provider configuration or via NGINX_SSH_HOST.")
+		return
+	}
+	if user == "" {
+		resp.Diagnostics.AddError("Missing User", "user must be set, either in provider configuration or via NGINX_SSH_USER.")
 		return
 	}
 
-	log.Println("[DEBUG] Client initialized successfully.")
-	resp.ResourceData = client
-}
+	log.Printf("[DEBUG] Initializing client for Host: %s, User: %s", host, user)
 
-// NewClient creates a new SSH client
-func NewClient(host, user, password string) (*Client, error) {
-	config := &ssh.ClientConfig{
-		User: user,
-		Auth: []ssh.AuthMethod{
-			ssh.Password(password),
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // Do not use in production
+	cfg := ClientConfig{
+		Host:                 host,
+		User:                 user,
+		Password:             password,
+		PrivateKey:           data.PrivateKey.ValueString(),
+		PrivateKeyPath:       data.PrivateKeyPath.ValueString(),
+		PrivateKeyPassphrase: data.PrivateKeyPassphrase.ValueString(),
+		UseSSHAgent:          data.UseSSHAgent.ValueBool(),
+		KnownHosts:           data.KnownHosts.ValueString(),
 	}
 
-	conn, err := ssh.Dial("tcp", fmt.Sprintf("%s:22", host), config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to SSH server: %w", err)
+	if data.Bastion != nil {
+		cfg.Bastion = &BastionConfig{
+			Host:                 data.Bastion.Host.ValueString(),
+			User:                 data.Bastion.User.ValueString(),
+			Password:             data.Bastion.Password.ValueString(),
+			PrivateKey:           data.Bastion.PrivateKey.ValueString(),
+			PrivateKeyPath:       data.Bastion.PrivateKeyPath.ValueString(),
+			PrivateKeyPassphrase: data.Bastion.PrivateKeyPassphrase.ValueString(),
+		}
 	}
 
-	return &Client{SSHClient: conn}, nil
-}
-
-// RunCommand executes a command on the remote server
-func (c *Client) RunCommand(command string) (string, error) {
-	session, err := c.SSHClient.NewSession()
+	client, err := NewClient(cfg)
 	if err != nil {
-		return "", fmt.Errorf("failed to create SSH session: %w", err)
+		resp.Diagnostics.AddError("Client Initialization Error", fmt.Sprintf("Unable to initialize SSH client: %s", err))
+		return
 	}
-	defer session.Close()
 
-	stdout, err := session.CombinedOutput(command)
-	if err != nil {
-		return "", fmt.Errorf("failed to execute command '%s': %w", command, err)
+	client.NginxConfPath = data.NginxConfPath.ValueString()
+	if client.NginxConfPath == "" {
+		client.NginxConfPath = defaultNginxConfPath
 	}
 
-	return string(stdout), nil
+	log.Println("[DEBUG] Client initialized successfully.")
+	resp.ResourceData = client
+	resp.DataSourceData = client
 }
 
-// Close closes the SSH connection
-func (c *Client) Close() error {
-	return c.SSHClient.Close()
+// stringOrEnv returns value's string, falling back to the named environment
+// variable when the attribute was not set in configuration.
+func stringOrEnv(value types.String, envVar string) string {
+	if !value.IsNull() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+	return os.Getenv(envVar)
 }
 
 func (p *NginxProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
-		// Add resources here
+		NewNginxConfResource,
+		NewNginxUpstreamResource,
+		NewNginxConfigurationResource,
+		NewNginxSiteResource,
+		NewNginxSSLCertificateResource,
+	}
+}
+
+func (p *NginxProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewNginxConfDataSource,
 	}
 }